@@ -0,0 +1,251 @@
+package maze
+
+import "math/rand/v2"
+
+// Algorithm carves passages into a CellGrid, turning it from all-walls into
+// a (typically perfect) maze. Implementations should visit every cell so
+// the resulting maze is fully connected.
+//
+// rect.Algorithm is the same interface carved over rect.MazeGenerator's
+// parallel grid model, and the implementations in rect/algorithms.go are
+// near-verbatim duplicates of the ones below; unifying the two grid
+// representations would let both packages share one set of algorithms.
+type Algorithm interface {
+	Carve(g *CellGrid, rng *rand.Rand)
+}
+
+// RecursiveBacktracker carves a maze with a randomized depth-first search,
+// producing long, winding corridors with relatively few dead ends. This is
+// the algorithm Generate has always used. It carves through a registered
+// Portal like any other adjacency, since it walks g.Neighbors.
+type RecursiveBacktracker struct{}
+
+func (RecursiveBacktracker) Carve(g *CellGrid, rng *rand.Rand) {
+	visited := make([][]bool, g.Rows)
+	for r := range visited {
+		visited[r] = make([]bool, g.Cols)
+	}
+
+	var walk func(r, c int)
+	walk = func(r, c int) {
+		visited[r][c] = true
+		neighbors := g.Neighbors(r, c)
+		rng.Shuffle(len(neighbors), func(i, j int) { neighbors[i], neighbors[j] = neighbors[j], neighbors[i] })
+		for _, n := range neighbors {
+			if !visited[n.R][n.C] {
+				g.Link(r, c, n.R, n.C)
+				walk(n.R, n.C)
+			}
+		}
+	}
+	walk(0, 0)
+}
+
+// BinaryTree carves a maze by, for every cell, randomly opening a passage
+// north or east. It is extremely fast but biases corridors into a diagonal
+// sweep and always leaves the north and east borders as long straight
+// hallways.
+type BinaryTree struct{}
+
+func (BinaryTree) Carve(g *CellGrid, rng *rand.Rand) {
+	for r := 0; r < g.Rows; r++ {
+		for c := 0; c < g.Cols; c++ {
+			canNorth := r > 0
+			canEast := c < g.Cols-1
+			switch {
+			case canNorth && canEast:
+				if rng.IntN(2) == 0 {
+					g.Link(r, c, r-1, c)
+				} else {
+					g.Link(r, c, r, c+1)
+				}
+			case canNorth:
+				g.Link(r, c, r-1, c)
+			case canEast:
+				g.Link(r, c, r, c+1)
+			}
+		}
+	}
+}
+
+// Sidewinder carves a maze row by row: it extends a horizontal "run" east
+// at random, and when a run closes, carves north from a random cell in the
+// run. Like BinaryTree it is fast, but the bias is horizontal runs instead
+// of a diagonal.
+type Sidewinder struct{}
+
+func (Sidewinder) Carve(g *CellGrid, rng *rand.Rand) {
+	for r := 0; r < g.Rows; r++ {
+		runStart := 0
+		for c := 0; c < g.Cols; c++ {
+			atEastBorder := c == g.Cols-1
+			atNorthBorder := r == 0
+
+			closeRun := atEastBorder || (!atNorthBorder && rng.IntN(2) == 0)
+			if closeRun {
+				if !atNorthBorder {
+					carveFrom := runStart + rng.IntN(c-runStart+1)
+					g.Link(r, carveFrom, r-1, carveFrom)
+				}
+				runStart = c + 1
+			} else {
+				g.Link(r, c, r, c+1)
+			}
+		}
+	}
+}
+
+// Wilson carves a maze using loop-erased random walks: starting from an
+// unvisited cell, it wanders randomly (erasing any loop it walks back into)
+// until it reaches a cell already part of the maze, then carves that walk.
+// Unlike RecursiveBacktracker it carries no structural bias toward long
+// corridors, producing a uniform spanning tree.
+type Wilson struct{}
+
+func (Wilson) Carve(g *CellGrid, rng *rand.Rand) {
+	inMaze := make([][]bool, g.Rows)
+	for r := range inMaze {
+		inMaze[r] = make([]bool, g.Cols)
+	}
+
+	all := make([]Coord, 0, g.Rows*g.Cols)
+	for r := 0; r < g.Rows; r++ {
+		for c := 0; c < g.Cols; c++ {
+			all = append(all, Coord{r, c})
+		}
+	}
+	start := all[rng.IntN(len(all))]
+	inMaze[start.R][start.C] = true
+
+	for _, cell := range all {
+		if inMaze[cell.R][cell.C] {
+			continue
+		}
+
+		// Loop-erased random walk from cell until it hits the maze.
+		path := []Coord{cell}
+		onPath := map[Coord]int{cell: 0}
+		cur := cell
+		for !inMaze[cur.R][cur.C] {
+			neighbors := g.Neighbors(cur.R, cur.C)
+			next := neighbors[rng.IntN(len(neighbors))]
+			if i, ok := onPath[next]; ok {
+				// Erase the loop back to the earlier visit.
+				path = path[:i+1]
+				for k := range onPath {
+					if onPath[k] > i {
+						delete(onPath, k)
+					}
+				}
+			} else {
+				onPath[next] = len(path)
+				path = append(path, next)
+			}
+			cur = next
+		}
+
+		for i := 0; i < len(path)-1; i++ {
+			a, b := path[i], path[i+1]
+			g.Link(a.R, a.C, b.R, b.C)
+			inMaze[a.R][a.C] = true
+		}
+		inMaze[path[len(path)-1].R][path[len(path)-1].C] = true
+	}
+}
+
+// Prim carves a maze using randomized Prim's algorithm: starting from a
+// random cell, it repeatedly grows the maze by linking a random frontier
+// cell to a random already-carved neighbor. This tends to produce many
+// short dead ends radiating from the start.
+type Prim struct{}
+
+func (Prim) Carve(g *CellGrid, rng *rand.Rand) {
+	inMaze := make([][]bool, g.Rows)
+	for r := range inMaze {
+		inMaze[r] = make([]bool, g.Cols)
+	}
+
+	start := Coord{rng.IntN(g.Rows), rng.IntN(g.Cols)}
+	inMaze[start.R][start.C] = true
+	frontier := g.Neighbors(start.R, start.C)
+
+	for len(frontier) > 0 {
+		i := rng.IntN(len(frontier))
+		cell := frontier[i]
+		frontier = append(frontier[:i], frontier[i+1:]...)
+		if inMaze[cell.R][cell.C] {
+			continue
+		}
+
+		var carvedNeighbors []Coord
+		for _, n := range g.Neighbors(cell.R, cell.C) {
+			if inMaze[n.R][n.C] {
+				carvedNeighbors = append(carvedNeighbors, n)
+			}
+		}
+		if len(carvedNeighbors) == 0 {
+			continue
+		}
+		neighbor := carvedNeighbors[rng.IntN(len(carvedNeighbors))]
+		g.Link(cell.R, cell.C, neighbor.R, neighbor.C)
+		inMaze[cell.R][cell.C] = true
+
+		for _, n := range g.Neighbors(cell.R, cell.C) {
+			if !inMaze[n.R][n.C] {
+				frontier = append(frontier, n)
+			}
+		}
+	}
+}
+
+// Kruskal carves a maze using randomized Kruskal's algorithm: it shuffles
+// every wall between adjacent cells and removes each one that would not
+// join two already-connected regions, tracked with a union-find structure.
+// It carves through a registered Portal like any other adjacency, since it
+// walks g.Neighbors.
+type Kruskal struct{}
+
+func (Kruskal) Carve(g *CellGrid, rng *rand.Rand) {
+	type edge struct{ a, b Coord }
+
+	seen := make(map[edge]bool)
+	var edges []edge
+	for r := 0; r < g.Rows; r++ {
+		for c := 0; c < g.Cols; c++ {
+			a := Coord{r, c}
+			for _, b := range g.Neighbors(r, c) {
+				e := edge{a, b}
+				if b.R < a.R || (b.R == a.R && b.C < a.C) {
+					e = edge{b, a}
+				}
+				if seen[e] {
+					continue
+				}
+				seen[e] = true
+				edges = append(edges, e)
+			}
+		}
+	}
+	rng.Shuffle(len(edges), func(i, j int) { edges[i], edges[j] = edges[j], edges[i] })
+
+	parent := make([]int, g.Rows*g.Cols)
+	for i := range parent {
+		parent[i] = i
+	}
+	idx := func(c Coord) int { return c.R*g.Cols + c.C }
+	var find func(i int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	for _, e := range edges {
+		ra, rb := find(idx(e.a)), find(idx(e.b))
+		if ra != rb {
+			parent[ra] = rb
+			g.Link(e.a.R, e.a.C, e.b.R, e.b.C)
+		}
+	}
+}
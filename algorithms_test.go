@@ -0,0 +1,84 @@
+package maze
+
+import "testing"
+
+// algorithmsUnderTest lists every generation Algorithm this package ships, so the
+// connectivity checks below run against all of them.
+var algorithmsUnderTest = []struct {
+	name string
+	algo Algorithm
+}{
+	{"RecursiveBacktracker", RecursiveBacktracker{}},
+	{"BinaryTree", BinaryTree{}},
+	{"Sidewinder", Sidewinder{}},
+	{"Wilson", Wilson{}},
+	{"Prim", Prim{}},
+	{"Kruskal", Kruskal{}},
+}
+
+// TestAlgorithmsProduceSpanningTree verifies every Algorithm carves a perfect
+// maze: every cell reachable from (0, 0), with exactly rows*cols-1 carved
+// edges (no loops, no disconnected cells). This is the invariant a buggy
+// union-find (Kruskal) or a buggy loop-erased walk (Wilson) would violate.
+func TestAlgorithmsProduceSpanningTree(t *testing.T) {
+	const rows, cols = 8, 8
+	for _, tc := range algorithmsUnderTest {
+		t.Run(tc.name, func(t *testing.T) {
+			m := NewMaze[uint8](cols, rows, 4, 1)
+			m.GenerateWith(tc.algo, 1, 2)
+
+			d := m.Distances(Coord{0, 0})
+			edges := 0
+			for r := 0; r < rows; r++ {
+				for c := 0; c < cols; c++ {
+					if _, ok := d.At(r, c); !ok {
+						t.Fatalf("cell (%d,%d) unreachable from (0,0)", r, c)
+					}
+					if m.cells.Carved(r, c, south) {
+						edges++
+					}
+					if m.cells.Carved(r, c, east) {
+						edges++
+					}
+				}
+			}
+			if want := rows*cols - 1; edges != want {
+				t.Fatalf("got %d carved edges, want %d (spanning tree has a loop or is disconnected)", edges, want)
+			}
+		})
+	}
+}
+
+// TestKruskalConsultsPortals guards against Kruskal building its edge list
+// from raw grid adjacency instead of g.Neighbors: a portal registered before
+// GenerateWith must be a candidate edge, just like for Prim and Wilson.
+func TestKruskalConsultsPortals(t *testing.T) {
+	m := NewMaze[uint8](4, 4, 4, 1)
+	if err := m.AddPortal(0, 0, 3, 3); err != nil {
+		t.Fatalf("AddPortal failed: %v", err)
+	}
+	m.GenerateWith(Kruskal{}, 1, 2)
+
+	if !m.Walkable(Coord{0, 0}, Coord{3, 3}) {
+		t.Fatal("Kruskal did not carve through the registered portal")
+	}
+
+	// Walkable is true for any registered portal, so also count the carved
+	// orthogonal edges: with seeds (1, 2) the shuffled portal edge joins
+	// (0,0) and (3,3) before the grid does, so the spanning tree uses it and
+	// carves one orthogonal edge fewer than rows*cols-1.
+	edges := 0
+	for r := 0; r < 4; r++ {
+		for c := 0; c < 4; c++ {
+			if m.cells.Carved(r, c, south) {
+				edges++
+			}
+			if m.cells.Carved(r, c, east) {
+				edges++
+			}
+		}
+	}
+	if want := 4*4 - 2; edges != want {
+		t.Fatalf("got %d carved orthogonal edges, want %d: the portal edge was not part of Kruskal's spanning tree", edges, want)
+	}
+}
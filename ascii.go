@@ -0,0 +1,121 @@
+package maze
+
+import (
+	"strings"
+
+	"golang.org/x/exp/constraints"
+)
+
+// RenderASCII renders m as plain ASCII text in the classic
+// "+---+---+" / "|   |" style, reading the logical wall state carved by the
+// last Generate/GenerateWith call rather than scanning pixels. m must have
+// been generated first.
+func RenderASCII[T constraints.Integer](m *Maze[T]) string {
+	cg := m.cells
+	var b strings.Builder
+
+	b.WriteString("+")
+	b.WriteString(strings.Repeat("---+", cg.Cols))
+	b.WriteString("\n")
+
+	for r := 0; r < cg.Rows; r++ {
+		top := strings.Builder{}
+		bottom := strings.Builder{}
+		top.WriteString("|")
+		bottom.WriteString("+")
+		for c := 0; c < cg.Cols; c++ {
+			top.WriteString("   ")
+			if cg.Carved(r, c, east) {
+				top.WriteString(" ")
+			} else {
+				top.WriteString("|")
+			}
+			if cg.Carved(r, c, south) {
+				bottom.WriteString("   +")
+			} else {
+				bottom.WriteString("---+")
+			}
+		}
+		b.WriteString(top.String())
+		b.WriteString("\n")
+		b.WriteString(bottom.String())
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+const (
+	boxHorizontal = '─' // ─
+	boxVertical   = '│' // │
+)
+
+// boxJunction maps which of the four directions a post connects to, to the
+// Unicode box-drawing rune for that junction.
+var boxJunction = map[[4]bool]rune{
+	{false, false, false, false}: ' ',
+	{true, false, false, false}:  '╵', // ╵ north
+	{false, true, false, false}:  '╷', // ╷ south
+	{false, false, true, false}:  '╶', // ╶ east
+	{false, false, false, true}:  '╴', // ╴ west
+	{true, false, true, false}:   '└', // └
+	{true, true, false, false}:   boxVertical,
+	{true, false, false, true}:   '┘', // ┘
+	{false, true, true, false}:   '┌', // ┌
+	{false, false, true, true}:   boxHorizontal,
+	{false, true, false, true}:   '┐', // ┐
+	{true, false, true, true}:    '┴', // ┴
+	{true, true, true, false}:    '├', // ├
+	{true, true, false, true}:    '┤', // ┤
+	{false, true, true, true}:    '┬', // ┬
+	{true, true, true, true}:     '┼', // ┼
+}
+
+// RenderBox renders m using Unicode box-drawing characters, reading the
+// logical wall state carved by the last Generate/GenerateWith call rather
+// than scanning pixels. m must have been generated first.
+func RenderBox[T constraints.Integer](m *Maze[T]) string {
+	cg := m.cells
+	h, w := 2*cg.Rows+1, 2*cg.Cols+1
+	canvas := make([][]rune, h)
+	for i := range canvas {
+		canvas[i] = make([]rune, w)
+		for j := range canvas[i] {
+			canvas[i][j] = ' '
+		}
+	}
+
+	for r := 0; r < cg.Rows; r++ {
+		for c := 0; c < cg.Cols; c++ {
+			if !cg.Carved(r, c, north) {
+				canvas[2*r][2*c+1] = boxHorizontal
+			}
+			if !cg.Carved(r, c, south) {
+				canvas[2*r+2][2*c+1] = boxHorizontal
+			}
+			if !cg.Carved(r, c, west) {
+				canvas[2*r+1][2*c] = boxVertical
+			}
+			if !cg.Carved(r, c, east) {
+				canvas[2*r+1][2*c+2] = boxVertical
+			}
+		}
+	}
+
+	for R := 0; R < h; R += 2 {
+		for C := 0; C < w; C += 2 {
+			var dirs [4]bool // north, south, east, west
+			dirs[0] = R > 0 && canvas[R-1][C] != ' '
+			dirs[1] = R < h-1 && canvas[R+1][C] != ' '
+			dirs[2] = C < w-1 && canvas[R][C+1] != ' '
+			dirs[3] = C > 0 && canvas[R][C-1] != ' '
+			canvas[R][C] = boxJunction[dirs]
+		}
+	}
+
+	lines := make([]string, h)
+	for i, row := range canvas {
+		lines[i] = string(row)
+	}
+	return strings.Join(lines, "\n")
+}
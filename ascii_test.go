@@ -0,0 +1,51 @@
+package maze
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+// fixedLAlgorithm carves a fixed, non-random layout on a 2x2 CellGrid: an
+// open L-shaped path from (0,0) east to (0,1) and south to (1,0), leaving
+// (1,1) walled off on every side. It exists purely so the golden tests
+// below pin against a known, hand-checkable wall layout instead of depending
+// on a particular RNG's output staying stable across Go versions.
+type fixedLAlgorithm struct{}
+
+func (fixedLAlgorithm) Carve(g *CellGrid, rng *rand.Rand) {
+	g.Link(0, 0, 0, 1)
+	g.Link(0, 0, 1, 0)
+}
+
+// TestRenderASCIIGolden pins RenderASCII's output against a fixed wall
+// layout so a change to the rendering itself (not to maze generation) gets
+// caught.
+func TestRenderASCIIGolden(t *testing.T) {
+	m := NewMaze[uint8](2, 2, 4, 1)
+	m.GenerateWith(fixedLAlgorithm{}, 1, 2)
+
+	want := "" +
+		"+---+---+\n" +
+		"|       |\n" +
+		"+   +---+\n" +
+		"|   |   |\n" +
+		"+---+---+\n"
+
+	if got := RenderASCII(m); got != want {
+		t.Fatalf("RenderASCII output changed:\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+// TestRenderBoxGolden is RenderASCII's golden test above, but for the
+// Unicode box-drawing renderer, which derives its junction glyphs from a
+// completely separate lookup table (boxJunction).
+func TestRenderBoxGolden(t *testing.T) {
+	m := NewMaze[uint8](2, 2, 4, 1)
+	m.GenerateWith(fixedLAlgorithm{}, 1, 2)
+
+	want := "┌───┐\n│   │\n│ ┌─┤\n│ │ │\n└─┴─┘"
+
+	if got := RenderBox(m); got != want {
+		t.Fatalf("RenderBox output changed:\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
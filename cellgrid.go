@@ -0,0 +1,143 @@
+package maze
+
+// Wall directions within a CellGrid.
+const (
+	north = iota
+	east
+	south
+	west
+)
+
+// Coord identifies a cell by row and column in a CellGrid.
+type Coord struct {
+	R, C int
+}
+
+// CellGrid is the logical, pixel-free representation of a maze: a grid of
+// cells joined by walls that an Algorithm carves through. Maze renders a
+// carved CellGrid into its pixel Grid via Generate/GenerateWith.
+type CellGrid struct {
+	Rows, Cols int
+	walls      [][][4]bool     // walls[r][c][dir], true = wall stands
+	portals    map[Coord]Coord // bidirectional portal links, see Maze.AddPortal
+}
+
+func newCellGrid(rows, cols int) *CellGrid {
+	cg := &CellGrid{Rows: rows, Cols: cols}
+	cg.walls = make([][][4]bool, rows)
+	for r := range cg.walls {
+		cg.walls[r] = make([][4]bool, cols)
+		for c := range cg.walls[r] {
+			cg.walls[r][c] = [4]bool{true, true, true, true}
+		}
+	}
+	return cg
+}
+
+// InBounds reports whether (r, c) is a valid cell in the grid.
+func (cg *CellGrid) InBounds(r, c int) bool {
+	return r >= 0 && r < cg.Rows && c >= 0 && c < cg.Cols
+}
+
+// Carved reports whether the wall on side dir of (r, c) has been removed.
+func (cg *CellGrid) Carved(r, c, dir int) bool {
+	return !cg.walls[r][c][dir]
+}
+
+// Link removes the wall between two orthogonally adjacent cells. It panics
+// if the cells are not adjacent.
+func (cg *CellGrid) Link(r1, c1, r2, c2 int) {
+	switch dr, dc := r2-r1, c2-c1; {
+	case dr == -1 && dc == 0:
+		cg.walls[r1][c1][north] = false
+		cg.walls[r2][c2][south] = false
+	case dr == 1 && dc == 0:
+		cg.walls[r1][c1][south] = false
+		cg.walls[r2][c2][north] = false
+	case dr == 0 && dc == 1:
+		cg.walls[r1][c1][east] = false
+		cg.walls[r2][c2][west] = false
+	case dr == 0 && dc == -1:
+		cg.walls[r1][c1][west] = false
+		cg.walls[r2][c2][east] = false
+	default:
+		// A generator walking portal-aware Neighbors may try to "carve" a
+		// portal link; portals have no wall to remove, so this is a no-op.
+		if partner, ok := cg.portals[Coord{r1, c1}]; ok && partner == (Coord{r2, c2}) {
+			return
+		}
+		panic("maze: Link requires orthogonally adjacent cells or a registered portal")
+	}
+}
+
+// addPortal registers a bidirectional portal link between a and b so that
+// Neighbors and PortalPartner expose it. It refuses to overwrite an endpoint
+// that is already part of a different portal (that would leave the old
+// partner's link stale and one-directional) and reports false in that case.
+func (cg *CellGrid) addPortal(a, b Coord) bool {
+	if existing, ok := cg.portals[a]; ok && existing != b {
+		return false
+	}
+	if existing, ok := cg.portals[b]; ok && existing != a {
+		return false
+	}
+	if cg.portals == nil {
+		cg.portals = make(map[Coord]Coord)
+	}
+	cg.portals[a] = b
+	cg.portals[b] = a
+	return true
+}
+
+// PortalPartner returns the other end of the portal at (r, c), if any.
+func (cg *CellGrid) PortalPartner(r, c int) (Coord, bool) {
+	partner, ok := cg.portals[Coord{r, c}]
+	return partner, ok
+}
+
+// Walkable reports whether a and b are orthogonally adjacent with no wall
+// carved between them, or linked by a portal.
+func (cg *CellGrid) Walkable(a, b Coord) bool {
+	for _, dir := range [4]int{north, east, south, west} {
+		nr, nc := step(a.R, a.C, dir)
+		if nr == b.R && nc == b.C {
+			return cg.Carved(a.R, a.C, dir)
+		}
+	}
+	partner, ok := cg.portals[a]
+	return ok && partner == b
+}
+
+// step returns the coordinate one cell away from (r, c) in direction dir.
+func step(r, c, dir int) (int, int) {
+	switch dir {
+	case north:
+		return r - 1, c
+	case south:
+		return r + 1, c
+	case east:
+		return r, c + 1
+	case west:
+		return r, c - 1
+	default:
+		return r, c
+	}
+}
+
+// Neighbors returns the in-bounds orthogonal neighbors of (r, c).
+// Neighbors includes a registered portal partner, if (r, c) has one, so
+// portal-aware Algorithms (those built on Neighbors, e.g. Prim, Kruskal,
+// Wilson) can carve straight through a portal like any other adjacency.
+func (cg *CellGrid) Neighbors(r, c int) []Coord {
+	candidates := [4]Coord{{r - 1, c}, {r, c + 1}, {r + 1, c}, {r, c - 1}}
+	neighbors := make([]Coord, 0, 5)
+	for _, n := range candidates {
+		if cg.InBounds(n.R, n.C) {
+			neighbors = append(neighbors, n)
+		}
+	}
+	if partner, ok := cg.portals[Coord{r, c}]; ok {
+		neighbors = append(neighbors, partner)
+	}
+	return neighbors
+}
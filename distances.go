@@ -0,0 +1,124 @@
+package maze
+
+var directions = [4]int{north, east, south, west}
+
+// Distances holds the per-cell distances from a single starting cell,
+// computed by a breadth-first flood fill over a maze's carved cells.
+type Distances struct {
+	cg   *CellGrid
+	from Coord
+	dist [][]int // -1 means unreached
+}
+
+// Distances runs a breadth-first flood fill from start over the cells
+// carved by the last Generate/GenerateWith call and returns the per-cell
+// distances. m must have been generated first.
+func (m *Maze[T]) Distances(start Coord) *Distances {
+	cg := m.cells
+	dist := make([][]int, cg.Rows)
+	for r := range dist {
+		dist[r] = make([]int, cg.Cols)
+		for c := range dist[r] {
+			dist[r][c] = -1
+		}
+	}
+	dist[start.R][start.C] = 0
+
+	queue := []Coord{start}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		for _, dir := range directions {
+			if !cg.Carved(cur.R, cur.C, dir) {
+				continue
+			}
+			nr, nc := step(cur.R, cur.C, dir)
+			if dist[nr][nc] != -1 {
+				continue
+			}
+			dist[nr][nc] = dist[cur.R][cur.C] + 1
+			queue = append(queue, Coord{nr, nc})
+		}
+		if partner, ok := cg.PortalPartner(cur.R, cur.C); ok && dist[partner.R][partner.C] == -1 {
+			dist[partner.R][partner.C] = dist[cur.R][cur.C] + 1
+			queue = append(queue, partner)
+		}
+	}
+
+	return &Distances{cg: cg, from: start, dist: dist}
+}
+
+// At returns the distance from the start cell to (r, c), and whether that
+// cell is reachable at all.
+func (d *Distances) At(r, c int) (int, bool) {
+	dist := d.dist[r][c]
+	return dist, dist != -1
+}
+
+// Farthest returns the reachable cell with the greatest distance from the
+// start cell.
+func (d *Distances) Farthest() Coord {
+	best := d.from
+	bestDist := 0
+	for r := range d.dist {
+		for c := range d.dist[r] {
+			if d.dist[r][c] > bestDist {
+				bestDist = d.dist[r][c]
+				best = Coord{r, c}
+			}
+		}
+	}
+	return best
+}
+
+// PathTo reconstructs the shortest corridor path from the start cell to
+// goal, walking back through neighbors of strictly decreasing distance. It
+// returns nil if goal is unreachable.
+func (d *Distances) PathTo(goal Coord) []Coord {
+	if _, ok := d.At(goal.R, goal.C); !ok {
+		return nil
+	}
+
+	path := []Coord{goal}
+	cur := goal
+	for cur != d.from {
+		curDist, _ := d.At(cur.R, cur.C)
+		prev := cur
+		for _, dir := range directions {
+			if !d.cg.Carved(cur.R, cur.C, dir) {
+				continue
+			}
+			nr, nc := step(cur.R, cur.C, dir)
+			if nd, ok := d.At(nr, nc); ok && nd == curDist-1 {
+				prev = Coord{nr, nc}
+				break
+			}
+		}
+		if prev == cur {
+			if partner, ok := d.cg.PortalPartner(cur.R, cur.C); ok {
+				if nd, ok := d.At(partner.R, partner.C); ok && nd == curDist-1 {
+					prev = partner
+				}
+			}
+		}
+		cur = prev
+		path = append(path, cur)
+	}
+
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+// LongestPath finds the graph diameter of the maze using two BFS passes:
+// the first locates the cell farthest from an arbitrary start, and the
+// second locates the cell farthest from that cell. Those two endpoints are
+// the natural start and goal of the hardest solution path through the
+// maze.
+func (m *Maze[T]) LongestPath() (start, goal Coord, path []Coord) {
+	start = m.Distances(Coord{0, 0}).Farthest()
+	d := m.Distances(start)
+	goal = d.Farthest()
+	return start, goal, d.PathTo(goal)
+}
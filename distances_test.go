@@ -0,0 +1,57 @@
+package maze
+
+import "testing"
+
+// TestPathToWalksWalkableSteps verifies PathTo returns a path that starts at
+// the origin, ends at the goal, and whose consecutive cells are each
+// connected by a carved wall or a portal, with length matching the BFS
+// distance.
+func TestPathToWalksWalkableSteps(t *testing.T) {
+	m := NewMaze[uint8](8, 8, 4, 1)
+	m.Generate(1, 2)
+
+	start := Coord{0, 0}
+	goal := Coord{7, 7}
+	d := m.Distances(start)
+	path := d.PathTo(goal)
+
+	wantLen, ok := d.At(goal.R, goal.C)
+	if !ok {
+		t.Fatal("goal reported unreachable by Distances.At")
+	}
+	if path[0] != start {
+		t.Fatalf("path starts at %v, want %v", path[0], start)
+	}
+	if path[len(path)-1] != goal {
+		t.Fatalf("path ends at %v, want %v", path[len(path)-1], goal)
+	}
+	if len(path) != wantLen+1 {
+		t.Fatalf("path has %d cells, want %d", len(path), wantLen+1)
+	}
+	for i := 0; i < len(path)-1; i++ {
+		if !m.Walkable(path[i], path[i+1]) {
+			t.Fatalf("path step %v -> %v is not walkable", path[i], path[i+1])
+		}
+	}
+}
+
+// TestPathToTerminatesWithPortals is a regression test for a Weave-induced
+// portal collision that used to leave PathTo's reconstruction loop with no
+// predecessor at a corrupted cell, looping forever. addPortal now refuses
+// the second, colliding registration, so every portal the walk traverses
+// has a consistent partner and PathTo always terminates.
+func TestPathToTerminatesWithPortals(t *testing.T) {
+	m := NewMaze[uint8](6, 6, 4, 1)
+	m.Generate(1, 2)
+	m.Weave(1) // maximum density: weave every eligible crossing
+
+	start, goal, path := m.LongestPath()
+	if path == nil {
+		t.Fatalf("LongestPath reported %v unreachable from %v", goal, start)
+	}
+	for i := 0; i < len(path)-1; i++ {
+		if !m.Walkable(path[i], path[i+1]) {
+			t.Fatalf("path step %v -> %v is not walkable", path[i], path[i+1])
+		}
+	}
+}
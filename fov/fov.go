@@ -0,0 +1,116 @@
+// fov is a subpackage for computing field-of-view over a maze's cell
+// adjacency, treating walls as opaque.
+package fov
+
+import (
+	"image"
+
+	"github.com/setanarut/maze"
+	"golang.org/x/exp/constraints"
+)
+
+// Compute returns the set of cells visible from origin within radius,
+// expressed in image.Point{X: col, Y: row} coordinates like the rest of the
+// package. A cell is visible if RayCast reports an unobstructed line of
+// sight to it from origin. m must have been generated first.
+func Compute[T constraints.Integer](m *maze.Maze[T], origin image.Point, radius int) map[image.Point]bool {
+	from := maze.Coord{R: origin.Y, C: origin.X}
+	visible := map[image.Point]bool{origin: true}
+
+	for dr := -radius; dr <= radius; dr++ {
+		for dc := -radius; dc <= radius; dc++ {
+			if dr*dr+dc*dc > radius*radius {
+				continue
+			}
+			to := maze.Coord{R: from.R + dr, C: from.C + dc}
+			if to.R < 0 || to.R >= m.Rows || to.C < 0 || to.C >= m.Cols {
+				continue
+			}
+			if RayCast(m, from, to) {
+				visible[image.Point{X: to.C, Y: to.R}] = true
+			}
+		}
+	}
+	return visible
+}
+
+// RayCast reports whether there is an unobstructed line of sight between
+// cells from and to. A single stepped line can cross a wall corner that a
+// differently-ordered line of the same length would have avoided (e.g. an
+// open L-shaped route through a concave corner, with the other L-route
+// walled), so RayCast tries two stepped lines, one breaking every tie
+// towards the row axis and one towards the column axis, and reports visible
+// if either of them is unobstructed.
+//
+// This is a deliberate choice of per-cell stepped-line visibility over
+// classic octant-based recursive shadow-casting. Shadow-casting assumes
+// cells are opaque blocks; this package's walls sit on cell *edges*, so an
+// adaptation would need a second, doubled-resolution grid to give walls
+// their own opaque cells, plus a rule for what a diagonal sight line does at
+// a wall corner shared by two differently-carved sides - exactly the
+// corner-casing this function already has to get right for a single step,
+// now needed at every recursion boundary instead of once. Prototyping that
+// version surfaced line-of-sight false positives (seeing through a carved
+// corner's diagonal neighbor) that this simpler approach doesn't have, for
+// no asymptotic win at the radii this package is used at in practice. If a
+// future caller needs shadow-casting's O(radius) scaling at large radii,
+// revisit this with that doubled-grid approach and budget real test
+// coverage for the corner cases.
+func RayCast[T constraints.Integer](m *maze.Maze[T], from, to maze.Coord) bool {
+	return walkClear(m, from, to, true) || walkClear(m, from, to, false)
+}
+
+// walkClear reports whether the stepped line from from to to is fully
+// unobstructed, tie-breaking ties towards the row axis when rowFirst is
+// true, and towards the column axis otherwise.
+func walkClear[T constraints.Integer](m *maze.Maze[T], from, to maze.Coord, rowFirst bool) bool {
+	absR, absC := abs(to.R-from.R), abs(to.C-from.C)
+	stepR, stepC := sign(to.R-from.R), sign(to.C-from.C)
+
+	cur := from
+	doneR, doneC := 0, 0
+	for cur != to {
+		var next maze.Coord
+		rowTie := doneR*absC <= doneC*absR
+		if !rowFirst {
+			rowTie = doneR*absC < doneC*absR
+		}
+		switch {
+		case doneR == absR:
+			next = maze.Coord{R: cur.R, C: cur.C + stepC}
+			doneC++
+		case doneC == absC:
+			next = maze.Coord{R: cur.R + stepR, C: cur.C}
+			doneR++
+		case rowTie:
+			next = maze.Coord{R: cur.R + stepR, C: cur.C}
+			doneR++
+		default:
+			next = maze.Coord{R: cur.R, C: cur.C + stepC}
+			doneC++
+		}
+		if !m.Walkable(cur, next) {
+			return false
+		}
+		cur = next
+	}
+	return true
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+func sign(n int) int {
+	switch {
+	case n > 0:
+		return 1
+	case n < 0:
+		return -1
+	default:
+		return 0
+	}
+}
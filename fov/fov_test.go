@@ -0,0 +1,43 @@
+package fov
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/setanarut/maze"
+)
+
+// cornerAlgorithm carves a fixed 2x2 concave corner: (0,0)-(0,1) and
+// (0,1)-(1,1) are open (one L-shaped route from (0,0) to (1,1)), while
+// (0,0)-(1,0) and (1,0)-(1,1) stay walled (the other L-shaped route is
+// closed).
+type cornerAlgorithm struct{}
+
+func (cornerAlgorithm) Carve(g *maze.CellGrid, rng *rand.Rand) {
+	g.Link(0, 0, 0, 1)
+	g.Link(0, 1, 1, 1)
+}
+
+// TestRayCastConcaveCorner is a regression test for a false negative where
+// RayCast trusted a single stepped line per target: its row-first tie-break
+// always tried to step into the walled (1,0) before reaching (1,1), even
+// though (1,1) is plainly visible via the open (0,0)->(0,1)->(1,1) route.
+func TestRayCastConcaveCorner(t *testing.T) {
+	m := maze.NewMaze[uint8](2, 2, 4, 1)
+	m.GenerateWith(cornerAlgorithm{}, 1, 2)
+
+	if !RayCast(m, maze.Coord{R: 0, C: 0}, maze.Coord{R: 1, C: 1}) {
+		t.Fatal("RayCast reported (1,1) not visible from (0,0), but the open L-route is clear")
+	}
+}
+
+// TestRayCastBlockedCorner checks the inverse: when both L-routes around a
+// corner are walled, the target really is occluded.
+func TestRayCastBlockedCorner(t *testing.T) {
+	m := maze.NewMaze[uint8](2, 2, 4, 1)
+	m.GenerateWith(cornerAlgorithm{}, 1, 2)
+
+	if RayCast(m, maze.Coord{R: 0, C: 1}, maze.Coord{R: 1, C: 0}) {
+		t.Fatal("RayCast reported (1,0) visible from (0,1), but both L-routes between them are walled")
+	}
+}
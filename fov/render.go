@@ -0,0 +1,40 @@
+package fov
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+
+	"github.com/setanarut/maze"
+	"golang.org/x/exp/constraints"
+)
+
+// WritePNGDimmed renders m like maze.WritePNG, but additionally darkens any
+// path cell not present in visible (as returned by Compute), so the PNG
+// reads as the maze seen from a single point of view.
+func WritePNGDimmed[T constraints.Integer](m *maze.Maze[T], visible map[image.Point]bool, filename string) error {
+	img := image.NewRGBA(image.Rect(0, 0, len(m.Grid[0]), len(m.Grid)))
+	for y, row := range m.Grid {
+		for x, cell := range row {
+			if cell == 1 {
+				img.Set(x, y, color.RGBA{0, 0, 255, 255})
+				continue
+			}
+
+			r, c := m.CellAt(x, y)
+			if visible[image.Point{X: c, Y: r}] {
+				img.Set(x, y, color.Gray{30})
+			} else {
+				img.Set(x, y, color.Gray{8})
+			}
+		}
+	}
+
+	outFile, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer outFile.Close()
+	return png.Encode(outFile, img)
+}
@@ -9,13 +9,17 @@ import (
 )
 
 type Maze[T constraints.Integer] struct {
-	Grid          [][]T      // 0: path, 1: wall
-	Visited       [][]bool   // visited cells for DFS
-	Rnd           *rand.Rand // random number generator
-	CellSize      int        // path width in pixels
-	WallThickness int        // wall thickness in pixels
-	Cols          int        // number of maze cells (width)
-	Rows          int        // number of maze cells (height)
+	Grid          [][]T           // 0: path, 1: wall
+	Visited       [][]bool        // every cell is marked visited by Generate/GenerateWith
+	Rnd           *rand.Rand      // random number generator
+	CellSize      int             // path width in pixels
+	WallThickness int             // wall thickness in pixels
+	Cols          int             // number of maze cells (width)
+	Rows          int             // number of maze cells (height)
+	Portals       []Portal        // registered portal links, see AddPortal
+	PortalID      [][]int         // PortalID[r][c]: 1-based index into Portals, 0 if none
+	Weaves        []WeaveCrossing // weave crossings carved by Weave
+	cells         *CellGrid       // logical cell/wall state carved by the last Generate/GenerateWith
 }
 
 func NewMaze[T constraints.Integer](w, h, cellSize, wallThickness int) *Maze[T] {
@@ -44,7 +48,16 @@ func NewMaze[T constraints.Integer](w, h, cellSize, wallThickness int) *Maze[T]
 	}
 }
 
+// Generate carves a maze using RecursiveBacktracker, the algorithm Generate
+// has always used. Use GenerateWith to pick a different Algorithm.
 func (m *Maze[T]) Generate(seed1 uint64, seed2 uint64) {
+	m.GenerateWith(RecursiveBacktracker{}, seed1, seed2)
+}
+
+// GenerateWith carves a maze using the given Algorithm. algo works against a
+// logical CellGrid of m.Rows x m.Cols cells; once it returns, the carved
+// cells are rendered into m.Grid at pixel resolution.
+func (m *Maze[T]) GenerateWith(algo Algorithm, seed1 uint64, seed2 uint64) {
 	m.Rnd = rand.New(rand.NewPCG(seed1, seed2))
 
 	// Reset matrix to all walls
@@ -54,101 +67,93 @@ func (m *Maze[T]) Generate(seed1 uint64, seed2 uint64) {
 		}
 	}
 
-	// Reset visited matrix
-	for i := range m.Visited {
-		for j := range m.Visited[i] {
-			m.Visited[i][j] = false
+	m.Weaves = nil
+
+	cg := newCellGrid(m.Rows, m.Cols)
+	for _, p := range m.Portals {
+		// AddPortal already rejects a colliding endpoint before it's ever
+		// appended to m.Portals, so this should never fail; skip instead
+		// of silently trusting an unchecked replay if it somehow does.
+		if !cg.addPortal(p.A, p.B) {
+			continue
 		}
 	}
-
-	// Start DFS from (0,0)
-	m.dfs(0, 0)
+	algo.Carve(cg, m.Rnd)
+	m.cells = cg
+	m.render(cg)
 }
 
-func (m *Maze[T]) dfs(r, c int) {
-	m.Visited[r][c] = true
-
-	// Fill cell area with path (0)
-	startY := m.WallThickness + r*(m.CellSize+m.WallThickness)
-	startX := m.WallThickness + c*(m.CellSize+m.WallThickness)
-	for y := range m.CellSize {
-		for x := range m.CellSize {
-			wy := startY + y
-			wx := startX + x
-			if wy >= 0 && wy < len(m.Grid) && wx >= 0 && wx < len(m.Grid[0]) {
-				m.Grid[wy][wx] = 0
+// render paints a carved CellGrid into the pixel Grid and marks every cell
+// as visited.
+func (m *Maze[T]) render(cg *CellGrid) {
+	for r := 0; r < m.Rows; r++ {
+		for c := 0; c < m.Cols; c++ {
+			m.Visited[r][c] = true
+
+			startY := m.WallThickness + r*(m.CellSize+m.WallThickness)
+			startX := m.WallThickness + c*(m.CellSize+m.WallThickness)
+
+			// Fill cell area with path (0)
+			for y := range m.CellSize {
+				for x := range m.CellSize {
+					wy, wx := startY+y, startX+x
+					if wy >= 0 && wy < len(m.Grid) && wx >= 0 && wx < len(m.Grid[0]) {
+						m.Grid[wy][wx] = 0
+					}
+				}
 			}
-		}
-	}
 
-	dirs := m.Rnd.Perm(4)
-	for _, dir := range dirs {
-		var nr, nc int
-		switch dir {
-		case 0: // up
-			nr, nc = r-1, c
-			if nr >= 0 && !m.Visited[nr][nc] {
-				// open wall above
+			if cg.Carved(r, c, north) {
 				for x := range m.CellSize {
 					for y := range m.WallThickness {
-						wy := startY - m.WallThickness + y
-						wx := startX + x
+						wy, wx := startY-m.WallThickness+y, startX+x
 						if wy >= 0 && wy < len(m.Grid) && wx >= 0 && wx < len(m.Grid[0]) {
 							m.Grid[wy][wx] = 0
 						}
 					}
 				}
-				m.dfs(nr, nc)
 			}
-		case 1: // left
-			nr, nc = r, c-1
-			if nc >= 0 && !m.Visited[nr][nc] {
-				// open wall to the left
+			if cg.Carved(r, c, west) {
 				for y := range m.CellSize {
 					for x := range m.WallThickness {
-						wy := startY + y
-						wx := startX - m.WallThickness + x
+						wy, wx := startY+y, startX-m.WallThickness+x
 						if wy >= 0 && wy < len(m.Grid) && wx >= 0 && wx < len(m.Grid[0]) {
 							m.Grid[wy][wx] = 0
 						}
 					}
 				}
-				m.dfs(nr, nc)
 			}
-		case 2: // down
-			nr, nc = r+1, c
-			if nr < m.Rows && !m.Visited[nr][nc] {
-				// open wall below
+			if cg.Carved(r, c, south) {
 				for x := range m.CellSize {
 					for y := range m.WallThickness {
-						wy := startY + m.CellSize + y
-						wx := startX + x
+						wy, wx := startY+m.CellSize+y, startX+x
 						if wy >= 0 && wy < len(m.Grid) && wx >= 0 && wx < len(m.Grid[0]) {
 							m.Grid[wy][wx] = 0
 						}
 					}
 				}
-				m.dfs(nr, nc)
 			}
-		case 3: // right
-			nr, nc = r, c+1
-			if nc < m.Cols && !m.Visited[nr][nc] {
-				// open wall to the right
+			if cg.Carved(r, c, east) {
 				for y := range m.CellSize {
 					for x := range m.WallThickness {
-						wy := startY + y
-						wx := startX + m.CellSize + x
+						wy, wx := startY+y, startX+m.CellSize+x
 						if wy >= 0 && wy < len(m.Grid) && wx >= 0 && wx < len(m.Grid[0]) {
 							m.Grid[wy][wx] = 0
 						}
 					}
 				}
-				m.dfs(nr, nc)
 			}
 		}
 	}
 }
 
+// Walkable reports whether a and b are orthogonally adjacent with no wall
+// carved between them, or linked by a portal. m must have been generated
+// first.
+func (m *Maze[T]) Walkable(a, b Coord) bool {
+	return m.cells.Walkable(a, b)
+}
+
 // Size returns the size of the maze in pixels.
 //
 // The size is calculated as the number of cells multiplied by the cell size,
@@ -0,0 +1,48 @@
+package maze
+
+import "fmt"
+
+// Portal is a pair of cells treated as adjacent by portal-aware generation
+// Algorithms and by Distances/PathTo, regardless of their grid position.
+type Portal struct {
+	A, B Coord
+}
+
+// AddPortal registers a portal connecting (r1, c1) and (r2, c2). It returns
+// an error, without modifying m.Portals or m.PortalID, if either cell is
+// already an endpoint of another portal: a cell can only have one partner.
+//
+// Call it before Generate/GenerateWith for a portal-aware carve: the
+// generator sees the portal as a normal adjacency and may carve the maze's
+// spanning tree straight through it (BinaryTree and Sidewinder, which don't
+// consult Neighbors, ignore portals). Call it after Generate/GenerateWith to
+// punch a portal into an already-carved maze instead, purely as an extra
+// connection for Distances/PathTo.
+func (m *Maze[T]) AddPortal(r1, c1, r2, c2 int) error {
+	inBounds := func(r, c int) bool { return r >= 0 && r < m.Rows && c >= 0 && c < m.Cols }
+	if !inBounds(r1, c1) || !inBounds(r2, c2) {
+		return fmt.Errorf("maze: AddPortal(%d, %d, %d, %d): cell out of bounds", r1, c1, r2, c2)
+	}
+
+	if m.PortalID == nil {
+		m.PortalID = make([][]int, m.Rows)
+		for r := range m.PortalID {
+			m.PortalID[r] = make([]int, m.Cols)
+		}
+	}
+
+	if m.PortalID[r1][c1] != 0 || m.PortalID[r2][c2] != 0 {
+		return fmt.Errorf("maze: AddPortal(%d, %d, %d, %d): a cell is already linked by another portal", r1, c1, r2, c2)
+	}
+
+	a, b := Coord{r1, c1}, Coord{r2, c2}
+	if m.cells != nil && !m.cells.addPortal(a, b) {
+		return fmt.Errorf("maze: AddPortal(%d, %d, %d, %d): a cell is already linked by another portal", r1, c1, r2, c2)
+	}
+
+	id := len(m.Portals) + 1
+	m.Portals = append(m.Portals, Portal{a, b})
+	m.PortalID[r1][c1] = id
+	m.PortalID[r2][c2] = id
+	return nil
+}
@@ -0,0 +1,64 @@
+package maze
+
+import "testing"
+
+// TestAddPortalRejectsCollidingCalls is a regression test for AddPortal and
+// GenerateWith's portal replay loop discarding addPortal's bool return: a
+// second AddPortal call colliding with an already-registered endpoint used
+// to still unconditionally append to m.Portals and overwrite m.PortalID,
+// even though the CellGrid-level link silently failed to register.
+func TestAddPortalRejectsCollidingCalls(t *testing.T) {
+	m := NewMaze[uint8](1, 3, 4, 1)
+
+	if err := m.AddPortal(0, 0, 1, 0); err != nil {
+		t.Fatalf("first AddPortal call should succeed, got: %v", err)
+	}
+	if err := m.AddPortal(1, 0, 2, 0); err == nil {
+		t.Fatal("second AddPortal call should fail: (1,0) is already a portal endpoint")
+	}
+
+	if len(m.Portals) != 1 {
+		t.Fatalf("m.Portals has %d entries, want 1: the rejected call must not append", len(m.Portals))
+	}
+	if id := m.PortalID[2][0]; id != 0 {
+		t.Fatalf("m.PortalID[2][0] = %d, want 0: the rejected call must not claim (2,0)", id)
+	}
+}
+
+// TestAddPortalRejectsCollisionAfterGenerate is the same regression as above,
+// but for portals added after Generate, where each accepted portal is also
+// registered on m.cells straight away.
+func TestAddPortalRejectsCollisionAfterGenerate(t *testing.T) {
+	m := NewMaze[uint8](1, 3, 4, 1)
+	m.Generate(1, 2)
+
+	if err := m.AddPortal(0, 0, 1, 0); err != nil {
+		t.Fatalf("first AddPortal call should succeed, got: %v", err)
+	}
+	if err := m.AddPortal(1, 0, 2, 0); err == nil {
+		t.Fatal("second AddPortal call should fail: (1,0) is already a portal endpoint")
+	}
+	if len(m.Portals) != 1 {
+		t.Fatalf("m.Portals has %d entries, want 1: the rejected call must not append", len(m.Portals))
+	}
+}
+
+// TestAddPortalRejectsOutOfBounds is a regression test for AddPortal
+// indexing m.PortalID with unchecked row/col and panicking on an
+// out-of-range cell instead of returning an error like it already does for
+// a colliding endpoint.
+func TestAddPortalRejectsOutOfBounds(t *testing.T) {
+	m := NewMaze[uint8](1, 3, 4, 1)
+
+	cases := [][4]int{
+		{-1, 0, 2, 2},
+		{0, 0, 1, 3},
+		{0, -1, 1, 0},
+		{0, 0, 1, 1},
+	}
+	for _, c := range cases {
+		if err := m.AddPortal(c[0], c[1], c[2], c[3]); err == nil {
+			t.Fatalf("AddPortal(%d, %d, %d, %d) should fail: out of bounds", c[0], c[1], c[2], c[3])
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package maze
+
+// Braid, CarveRoom, and Weave are post-processing passes that run after
+// Generate/GenerateWith and break the maze's "one true path" tree structure
+// into something with loops, open rooms, and crossings. Apply Weave last:
+// unlike Braid and CarveRoom it doesn't touch any wall, so it doesn't need
+// to repaint m.Grid from scratch and won't lose the bridge shadows drawn by
+// an earlier Weave call.
+
+// Braid removes dead ends by knocking down a random wall at each leaf cell,
+// turning roughly a p fraction of them (p should be in [0, 1]) into part of
+// a loop instead. m must have been generated first.
+func (m *Maze[T]) Braid(p float64) {
+	cg := m.cells
+	for r := 0; r < cg.Rows; r++ {
+		for c := 0; c < cg.Cols; c++ {
+			carved := 0
+			for _, dir := range directions {
+				if cg.Carved(r, c, dir) {
+					carved++
+				}
+			}
+			if carved != 1 || m.Rnd.Float64() >= p {
+				continue
+			}
+
+			var candidates []int
+			for _, dir := range directions {
+				if cg.Carved(r, c, dir) {
+					continue
+				}
+				if nr, nc := step(r, c, dir); cg.InBounds(nr, nc) {
+					candidates = append(candidates, dir)
+				}
+			}
+			if len(candidates) == 0 {
+				continue
+			}
+
+			dir := candidates[m.Rnd.IntN(len(candidates))]
+			nr, nc := step(r, c, dir)
+			cg.Link(r, c, nr, nc)
+		}
+	}
+	m.render(cg)
+}
+
+// CarveRoom clears the rectangular block of cells starting at (r, c) with
+// the given width and height into one open room, by carving every internal
+// wall between cells inside the block. Walls on the room's perimeter are
+// left as the generator carved them, which is what reconnects the room to
+// the surrounding corridors (the spanning tree already guarantees at least
+// one carved passage crossing the perimeter). m must have been generated
+// first.
+func (m *Maze[T]) CarveRoom(r, c, w, h int) {
+	cg := m.cells
+	for rr := r; rr < r+h; rr++ {
+		for cc := c; cc < c+w; cc++ {
+			if !cg.InBounds(rr, cc) {
+				continue
+			}
+			if cc+1 < c+w && cg.InBounds(rr, cc+1) {
+				cg.Link(rr, cc, rr, cc+1)
+			}
+			if rr+1 < r+h && cg.InBounds(rr+1, cc) {
+				cg.Link(rr, cc, rr+1, cc)
+			}
+		}
+	}
+	m.render(cg)
+}
+
+// WeaveCrossing records one cell where Weave wove a corridor over or under
+// another: the carved-through axis (Over true means north-south) owns the
+// physical cell, while the perpendicular corridor is linked directly
+// between the cells on either side, bypassing it entirely.
+type WeaveCrossing struct {
+	Cell Coord
+	Over bool
+}
+
+// Weave introduces over/under crossings: at interior cells where exactly
+// one axis runs straight through (both opposite walls carved, and neither
+// wall of the other axis carved at all) it links the two cells on the
+// un-carved axis directly to each other, bypassing the crossing cell as a
+// graph node so the two corridors pass through the same cell without
+// connecting. A cell with a real carved edge on the other axis (e.g. a
+// T-junction) is not eligible, since it already owns a direct edge on that
+// side and weaving it would give the cell two inconsistent links along the
+// same axis. density is the
+// probability, in [0, 1], that any single eligible cell is woven. Weave
+// marks each crossing cell with a small bridge-shadow patch in m.Grid
+// (rendered as a distinct tile value by WritePNG) and records it in
+// m.Weaves. If an eligible cell's bypass would land on a cell that's already
+// a portal endpoint (e.g. from an earlier crossing in the same Weave call),
+// it is skipped rather than woven, since a portal can only have one partner.
+// m must have been generated first.
+func (m *Maze[T]) Weave(density float64) {
+	cg := m.cells
+	for r := 1; r < cg.Rows-1; r++ {
+		for c := 1; c < cg.Cols-1; c++ {
+			northSouth := cg.Carved(r, c, north) && cg.Carved(r, c, south) && !cg.Carved(r, c, east) && !cg.Carved(r, c, west)
+			eastWest := cg.Carved(r, c, east) && cg.Carved(r, c, west) && !cg.Carved(r, c, north) && !cg.Carved(r, c, south)
+			if northSouth == eastWest || m.Rnd.Float64() >= density {
+				continue
+			}
+
+			var a, b Coord
+			if northSouth {
+				a, b = Coord{r, c - 1}, Coord{r, c + 1}
+			} else {
+				a, b = Coord{r - 1, c}, Coord{r + 1, c}
+			}
+			if !cg.addPortal(a, b) {
+				continue
+			}
+			m.Weaves = append(m.Weaves, WeaveCrossing{Cell: Coord{r, c}, Over: northSouth})
+			m.paintBridgeShadow(r, c)
+		}
+	}
+}
+
+// paintBridgeShadow marks the center of cell (r, c) in m.Grid with a
+// distinct tile value so WritePNG can render a weave crossing differently
+// from an ordinary path cell.
+func (m *Maze[T]) paintBridgeShadow(r, c int) {
+	shadow := m.CellSize / 3
+	if shadow < 1 {
+		shadow = 1
+	}
+	pad := (m.CellSize - shadow) / 2
+	startY := m.WallThickness + r*(m.CellSize+m.WallThickness)
+	startX := m.WallThickness + c*(m.CellSize+m.WallThickness)
+
+	for y := pad; y < pad+shadow; y++ {
+		for x := pad; x < pad+shadow; x++ {
+			wy, wx := startY+y, startX+x
+			if wy >= 0 && wy < len(m.Grid) && wx >= 0 && wx < len(m.Grid[0]) {
+				m.Grid[wy][wx] = 2
+			}
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package maze
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+// tJunctionAlgorithm carves a fixed 3x3 layout where the center cell (1,1)
+// is a T-junction: north and south are carved (straight through), and so is
+// east (a real, direct edge), leaving only west un-carved. It exists to
+// check that Weave does not mistake a T-junction for an eligible crossing.
+type tJunctionAlgorithm struct{}
+
+func (tJunctionAlgorithm) Carve(g *CellGrid, rng *rand.Rand) {
+	g.Link(0, 1, 1, 1)
+	g.Link(1, 1, 2, 1)
+	g.Link(1, 1, 1, 2)
+}
+
+// TestWeaveSkipsTJunction is a regression test for Weave's eligibility check
+// treating "perpendicular axis not fully carved" as "perpendicular axis
+// fully un-carved": a T-junction cell (one real carved edge on the
+// perpendicular axis, the other side still walled) used to pass the check
+// and get bypassed by a portal even though it already owns a direct edge on
+// that same axis.
+func TestWeaveSkipsTJunction(t *testing.T) {
+	m := NewMaze[uint8](3, 3, 4, 1)
+	m.GenerateWith(tJunctionAlgorithm{}, 1, 2)
+	m.Weave(1) // maximum density: weave every eligible crossing
+
+	for _, w := range m.Weaves {
+		if w.Cell == (Coord{1, 1}) {
+			t.Fatal("Weave wove the T-junction cell (1,1), which already owns a direct east edge")
+		}
+	}
+	if _, ok := m.cells.PortalPartner(1, 0); ok {
+		t.Fatal("Weave registered a portal bypassing the T-junction cell (1,1)")
+	}
+	if !m.Walkable(Coord{1, 1}, Coord{1, 2}) {
+		t.Fatal("the T-junction cell's real east edge should remain walkable")
+	}
+}
+
+// TestAddPortalRejectsCollision is a regression test for the Weave bug where
+// a second crossing's bypass cell could land on a cell already claimed by an
+// earlier crossing, silently overwriting its portal entry and leaving the
+// original partner pointing at a cell that no longer pointed back.
+func TestAddPortalRejectsCollision(t *testing.T) {
+	cg := newCellGrid(3, 1)
+
+	if ok := cg.addPortal(Coord{0, 0}, Coord{1, 0}); !ok {
+		t.Fatal("first addPortal call should succeed")
+	}
+	if ok := cg.addPortal(Coord{1, 0}, Coord{2, 0}); ok {
+		t.Fatal("addPortal should refuse to overwrite an existing portal endpoint")
+	}
+
+	partner, ok := cg.PortalPartner(1, 0)
+	if !ok || partner != (Coord{0, 0}) {
+		t.Fatalf("PortalPartner(1,0) = %v, %v, want {0 0}, true", partner, ok)
+	}
+	if _, ok := cg.PortalPartner(2, 0); ok {
+		t.Fatal("PortalPartner(2,0) should be unset: the colliding registration must not have touched it")
+	}
+}
+
+// countDeadEnds returns the number of cells in cg with exactly one carved
+// wall: a corridor end with nowhere else to go.
+func countDeadEnds(cg *CellGrid) int {
+	deadEnds := 0
+	for r := 0; r < cg.Rows; r++ {
+		for c := 0; c < cg.Cols; c++ {
+			carved := 0
+			for _, dir := range directions {
+				if cg.Carved(r, c, dir) {
+					carved++
+				}
+			}
+			if carved == 1 {
+				deadEnds++
+			}
+		}
+	}
+	return deadEnds
+}
+
+// TestBraidRemovesDeadEnds is a regression test for Braid(1): at maximum
+// density every dead end must be knocked down into part of a loop, leaving
+// zero cells with exactly one carved wall.
+func TestBraidRemovesDeadEnds(t *testing.T) {
+	m := NewMaze[uint8](8, 8, 4, 1)
+	m.Generate(1, 2)
+
+	if countDeadEnds(m.cells) == 0 {
+		t.Fatal("freshly generated maze has no dead ends; test can't tell Braid apart from a no-op")
+	}
+
+	m.Braid(1)
+	if got := countDeadEnds(m.cells); got != 0 {
+		t.Fatalf("Braid(1) left %d dead ends, want 0", got)
+	}
+}
+
+// TestBraidRespectsZeroP is a regression test for Braid(0): a zero braiding
+// probability must leave every dead end untouched.
+func TestBraidRespectsZeroP(t *testing.T) {
+	m := NewMaze[uint8](8, 8, 4, 1)
+	m.Generate(1, 2)
+
+	before := countDeadEnds(m.cells)
+	m.Braid(0)
+	if after := countDeadEnds(m.cells); after != before {
+		t.Fatalf("Braid(0) changed dead-end count from %d to %d, want no change", before, after)
+	}
+}
+
+// TestCarveRoomClearsInternalWallsAndPreservesPerimeter is a regression test
+// for CarveRoom: every pair of orthogonally adjacent cells inside the
+// requested block must become walkable, while the cells just outside the
+// block keep whatever the generator carved on the perimeter (CarveRoom
+// touches no wall outside the block).
+func TestCarveRoomClearsInternalWallsAndPreservesPerimeter(t *testing.T) {
+	m := NewMaze[uint8](8, 8, 4, 1)
+	m.Generate(1, 2)
+
+	const r, c, w, h = 2, 2, 3, 3
+	perimeterBefore := m.cells.Carved(r, c, north)
+
+	m.CarveRoom(r, c, w, h)
+
+	for rr := r; rr < r+h; rr++ {
+		for cc := c; cc < c+w; cc++ {
+			if cc+1 < c+w && !m.cells.Walkable(Coord{rr, cc}, Coord{rr, cc + 1}) {
+				t.Fatalf("(%d,%d) and (%d,%d) should be walkable after CarveRoom", rr, cc, rr, cc+1)
+			}
+			if rr+1 < r+h && !m.cells.Walkable(Coord{rr, cc}, Coord{rr + 1, cc}) {
+				t.Fatalf("(%d,%d) and (%d,%d) should be walkable after CarveRoom", rr, cc, rr+1, cc)
+			}
+		}
+	}
+
+	if got := m.cells.Carved(r, c, north); got != perimeterBefore {
+		t.Fatalf("CarveRoom changed the perimeter wall north of (%d,%d): got carved=%v, want %v", r, c, got, perimeterBefore)
+	}
+}
@@ -0,0 +1,228 @@
+package rect
+
+// Algorithm carves passages into a MazeGenerator's grid, turning it from
+// all-walls into a (typically perfect) maze. Implementations should visit
+// every cell so the resulting maze is fully connected.
+//
+// maze.Algorithm is the same interface carved over the top-level maze
+// package's parallel CellGrid model, and the implementations below are
+// near-verbatim duplicates of the ones in algorithms.go there; unifying
+// the two grid representations would let both packages share one set of
+// algorithms.
+type Algorithm interface {
+	Carve(mg *MazeGenerator)
+}
+
+// RecursiveBacktracker carves a maze with a randomized depth-first search,
+// producing long, winding corridors with relatively few dead ends. This is
+// the algorithm GenerateMaze has always used.
+type RecursiveBacktracker struct{}
+
+func (RecursiveBacktracker) Carve(mg *MazeGenerator) {
+	stack := []*Cell{&mg.Grid[0][0]}
+	mg.Grid[0][0].Visited = true
+
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+
+		neighbors := mg.getUnvisitedNeighbors(current)
+		if len(neighbors) > 0 {
+			next := neighbors[mg.rng.Intn(len(neighbors))]
+			mg.removeWall(current, next)
+			next.Visited = true
+			stack = append(stack, next)
+		} else {
+			stack = stack[:len(stack)-1]
+		}
+	}
+}
+
+// BinaryTree carves a maze by, for every cell, randomly opening a passage
+// north or east. It is extremely fast but biases corridors into a diagonal
+// sweep and always leaves the north and east borders as long straight
+// hallways.
+type BinaryTree struct{}
+
+func (BinaryTree) Carve(mg *MazeGenerator) {
+	for y := 0; y < mg.Height; y++ {
+		for x := 0; x < mg.Width; x++ {
+			cell := &mg.Grid[y][x]
+			canNorth := y > 0
+			canEast := x < mg.Width-1
+			switch {
+			case canNorth && canEast:
+				if mg.rng.Intn(2) == 0 {
+					mg.removeWall(cell, &mg.Grid[y-1][x])
+				} else {
+					mg.removeWall(cell, &mg.Grid[y][x+1])
+				}
+			case canNorth:
+				mg.removeWall(cell, &mg.Grid[y-1][x])
+			case canEast:
+				mg.removeWall(cell, &mg.Grid[y][x+1])
+			}
+		}
+	}
+}
+
+// Sidewinder carves a maze row by row: it extends a horizontal "run" east
+// at random, and when a run closes, carves north from a random cell in the
+// run. Like BinaryTree it is fast, but the bias is horizontal runs instead
+// of a diagonal.
+type Sidewinder struct{}
+
+func (Sidewinder) Carve(mg *MazeGenerator) {
+	for y := 0; y < mg.Height; y++ {
+		runStart := 0
+		for x := 0; x < mg.Width; x++ {
+			atEastBorder := x == mg.Width-1
+			atNorthBorder := y == 0
+
+			closeRun := atEastBorder || (!atNorthBorder && mg.rng.Intn(2) == 0)
+			if closeRun {
+				if !atNorthBorder {
+					carveFrom := runStart + mg.rng.Intn(x-runStart+1)
+					mg.removeWall(&mg.Grid[y][carveFrom], &mg.Grid[y-1][carveFrom])
+				}
+				runStart = x + 1
+			} else {
+				mg.removeWall(&mg.Grid[y][x], &mg.Grid[y][x+1])
+			}
+		}
+	}
+}
+
+// Wilson carves a maze using loop-erased random walks: starting from an
+// unvisited cell, it wanders randomly (erasing any loop it walks back into)
+// until it reaches a cell already part of the maze, then carves that walk.
+// Unlike RecursiveBacktracker it carries no structural bias toward long
+// corridors, producing a uniform spanning tree.
+type Wilson struct{}
+
+func (Wilson) Carve(mg *MazeGenerator) {
+	var all []*Cell
+	for y := range mg.Grid {
+		for x := range mg.Grid[y] {
+			all = append(all, &mg.Grid[y][x])
+		}
+	}
+	start := all[mg.rng.Intn(len(all))]
+	start.Visited = true
+
+	for _, cell := range all {
+		if cell.Visited {
+			continue
+		}
+
+		// Loop-erased random walk from cell until it hits the maze.
+		path := []*Cell{cell}
+		onPath := map[*Cell]int{cell: 0}
+		cur := cell
+		for !cur.Visited {
+			neighbors := mg.getNeighbors(cur)
+			next := neighbors[mg.rng.Intn(len(neighbors))]
+			if i, ok := onPath[next]; ok {
+				// Erase the loop back to the earlier visit.
+				path = path[:i+1]
+				for k := range onPath {
+					if onPath[k] > i {
+						delete(onPath, k)
+					}
+				}
+			} else {
+				onPath[next] = len(path)
+				path = append(path, next)
+			}
+			cur = next
+		}
+
+		for i := 0; i < len(path)-1; i++ {
+			mg.removeWall(path[i], path[i+1])
+			path[i].Visited = true
+		}
+		path[len(path)-1].Visited = true
+	}
+}
+
+// Prim carves a maze using randomized Prim's algorithm: starting from a
+// random cell, it repeatedly grows the maze by linking a random frontier
+// cell to a random already-carved neighbor. This tends to produce many
+// short dead ends radiating from the start.
+type Prim struct{}
+
+func (Prim) Carve(mg *MazeGenerator) {
+	start := &mg.Grid[mg.rng.Intn(mg.Height)][mg.rng.Intn(mg.Width)]
+	start.Visited = true
+	frontier := mg.getNeighbors(start)
+
+	for len(frontier) > 0 {
+		i := mg.rng.Intn(len(frontier))
+		cell := frontier[i]
+		frontier = append(frontier[:i], frontier[i+1:]...)
+		if cell.Visited {
+			continue
+		}
+
+		var carvedNeighbors []*Cell
+		for _, n := range mg.getNeighbors(cell) {
+			if n.Visited {
+				carvedNeighbors = append(carvedNeighbors, n)
+			}
+		}
+		if len(carvedNeighbors) == 0 {
+			continue
+		}
+		neighbor := carvedNeighbors[mg.rng.Intn(len(carvedNeighbors))]
+		mg.removeWall(cell, neighbor)
+		cell.Visited = true
+
+		for _, n := range mg.getNeighbors(cell) {
+			if !n.Visited {
+				frontier = append(frontier, n)
+			}
+		}
+	}
+}
+
+// Kruskal carves a maze using randomized Kruskal's algorithm: it shuffles
+// every wall between adjacent cells and removes each one that would not
+// join two already-connected regions, tracked with a union-find structure.
+type Kruskal struct{}
+
+func (Kruskal) Carve(mg *MazeGenerator) {
+	type edge struct{ a, b *Cell }
+
+	var edges []edge
+	for y := 0; y < mg.Height; y++ {
+		for x := 0; x < mg.Width; x++ {
+			if x < mg.Width-1 {
+				edges = append(edges, edge{&mg.Grid[y][x], &mg.Grid[y][x+1]})
+			}
+			if y < mg.Height-1 {
+				edges = append(edges, edge{&mg.Grid[y][x], &mg.Grid[y+1][x]})
+			}
+		}
+	}
+	mg.rng.Shuffle(len(edges), func(i, j int) { edges[i], edges[j] = edges[j], edges[i] })
+
+	parent := make([]int, mg.Width*mg.Height)
+	for i := range parent {
+		parent[i] = i
+	}
+	idx := func(c *Cell) int { return c.Y*mg.Width + c.X }
+	var find func(i int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	for _, e := range edges {
+		ra, rb := find(idx(e.a)), find(idx(e.b))
+		if ra != rb {
+			parent[ra] = rb
+			mg.removeWall(e.a, e.b)
+		}
+	}
+}
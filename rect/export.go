@@ -0,0 +1,184 @@
+package rect
+
+import (
+	"fmt"
+	"image"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// mergeWalls coalesces collinear, touching-or-overlapping wall rectangles
+// into single long segments, so downstream physics engines don't get
+// hundreds of tiny colliders for what is visually one wall.
+func mergeWalls(walls []image.Rectangle) []image.Rectangle {
+	var horizontal, vertical []image.Rectangle
+	for _, w := range walls {
+		if w.Dx() >= w.Dy() {
+			horizontal = append(horizontal, w)
+		} else {
+			vertical = append(vertical, w)
+		}
+	}
+
+	merge1D := func(rects []image.Rectangle, isHorizontal bool) []image.Rectangle {
+		type key struct{ a, b int }
+		byBand := map[key][]image.Rectangle{}
+		for _, r := range rects {
+			var k key
+			if isHorizontal {
+				k = key{r.Min.Y, r.Max.Y}
+			} else {
+				k = key{r.Min.X, r.Max.X}
+			}
+			byBand[k] = append(byBand[k], r)
+		}
+
+		var out []image.Rectangle
+		for _, group := range byBand {
+			if isHorizontal {
+				sort.Slice(group, func(i, j int) bool { return group[i].Min.X < group[j].Min.X })
+			} else {
+				sort.Slice(group, func(i, j int) bool { return group[i].Min.Y < group[j].Min.Y })
+			}
+
+			cur := group[0]
+			for _, next := range group[1:] {
+				touching := (isHorizontal && next.Min.X <= cur.Max.X) || (!isHorizontal && next.Min.Y <= cur.Max.Y)
+				if touching {
+					cur = cur.Union(next)
+					continue
+				}
+				out = append(out, cur)
+				cur = next
+			}
+			out = append(out, cur)
+		}
+		return out
+	}
+
+	out := merge1D(horizontal, true)
+	out = append(out, merge1D(vertical, false)...)
+	return out
+}
+
+// WriteWallPolylines converts walls into barrier polylines suitable for
+// Lua-scripted level generators (in the spirit of Bitfighter's mazegen,
+// which expects grid size, an upper-left corner, and a cellsize): each
+// merged wall segment becomes a two-point centerline polyline, scaled by
+// cellSize and offset by origin.
+func WriteWallPolylines(walls []image.Rectangle, origin image.Point, cellSize float64) [][]image.Point {
+	merged := mergeWalls(walls)
+	transform := func(x, y int) image.Point {
+		return image.Point{
+			X: origin.X + int(float64(x)*cellSize),
+			Y: origin.Y + int(float64(y)*cellSize),
+		}
+	}
+
+	polylines := make([][]image.Point, 0, len(merged))
+	for _, w := range merged {
+		var p0, p1 image.Point
+		if w.Dx() >= w.Dy() {
+			cy := (w.Min.Y + w.Max.Y) / 2
+			p0, p1 = transform(w.Min.X, cy), transform(w.Max.X, cy)
+		} else {
+			cx := (w.Min.X + w.Max.X) / 2
+			p0, p1 = transform(cx, w.Min.Y), transform(cx, w.Max.Y)
+		}
+		polylines = append(polylines, []image.Point{p0, p1})
+	}
+	return polylines
+}
+
+// WriteSVG writes walls (merged into long segments) as scalable vector
+// output.
+func WriteSVG(walls []image.Rectangle, filename string) error {
+	merged := mergeWalls(walls)
+
+	var bounds image.Rectangle
+	for _, w := range merged {
+		bounds = bounds.Union(w)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%d\" height=\"%d\" viewBox=\"0 0 %d %d\">\n",
+		bounds.Max.X, bounds.Max.Y, bounds.Max.X, bounds.Max.Y)
+	fmt.Fprintf(&b, "  <rect width=\"100%%\" height=\"100%%\" fill=\"black\"/>\n")
+	for _, w := range merged {
+		fmt.Fprintf(&b, "  <rect x=\"%d\" y=\"%d\" width=\"%d\" height=\"%d\" fill=\"white\"/>\n",
+			w.Min.X, w.Min.Y, w.Dx(), w.Dy())
+	}
+	b.WriteString("</svg>\n")
+
+	return os.WriteFile(filename, []byte(b.String()), 0644)
+}
+
+// TMXOptions configures WriteTMX.
+type TMXOptions struct {
+	TileSize      int    // size of a square tile in pixels; also the map's tilewidth/tileheight
+	WallGID       int    // tile gid written where a wall covers a tile; 0 defaults to 1
+	TilesetSource string // optional path to a .tsx tileset referenced by the map; omitted if empty
+}
+
+// WriteTMX rasterizes walls onto a TileSize grid spanning bounds and writes
+// a Tiled .tmx map with a single wall tile layer. A tile is classified as
+// wall by sampling its center point against the wall rectangles, not by any
+// overlap: a wall rectangle runs the full width (or height) of a cell but
+// only WallThickness deep, so at a realistic TileSize == CellSize it only
+// ever grazes the edge of the tile it borders, never its center.
+func WriteTMX(walls []image.Rectangle, bounds image.Point, filename string, opts TMXOptions) error {
+	wallGID := opts.WallGID
+	if wallGID == 0 {
+		wallGID = 1
+	}
+
+	cols := (bounds.X + opts.TileSize - 1) / opts.TileSize
+	rows := (bounds.Y + opts.TileSize - 1) / opts.TileSize
+	grid := make([][]int, rows)
+	for i := range grid {
+		grid[i] = make([]int, cols)
+	}
+
+	for ty := 0; ty < rows; ty++ {
+		for tx := 0; tx < cols; tx++ {
+			center := image.Point{
+				X: tx*opts.TileSize + opts.TileSize/2,
+				Y: ty*opts.TileSize + opts.TileSize/2,
+			}
+			for _, w := range walls {
+				if center.In(w) {
+					grid[ty][tx] = wallGID
+					break
+				}
+			}
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString("<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n")
+	fmt.Fprintf(&b, "<map version=\"1.10\" orientation=\"orthogonal\" renderorder=\"right-down\" width=\"%d\" height=\"%d\" tilewidth=\"%d\" tileheight=\"%d\" infinite=\"0\" nextlayerid=\"2\" nextobjectid=\"1\">\n",
+		cols, rows, opts.TileSize, opts.TileSize)
+	if opts.TilesetSource != "" {
+		fmt.Fprintf(&b, "  <tileset firstgid=\"%d\" source=\"%s\"/>\n", wallGID, opts.TilesetSource)
+	}
+	fmt.Fprintf(&b, "  <layer id=\"1\" name=\"walls\" width=\"%d\" height=\"%d\">\n", cols, rows)
+	b.WriteString("    <data encoding=\"csv\">\n")
+	for ty, row := range grid {
+		cells := make([]string, len(row))
+		for tx, gid := range row {
+			cells[tx] = strconv.Itoa(gid)
+		}
+		b.WriteString(strings.Join(cells, ","))
+		if ty < len(grid)-1 {
+			b.WriteString(",")
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("    </data>\n")
+	b.WriteString("  </layer>\n")
+	b.WriteString("</map>\n")
+
+	return os.WriteFile(filename, []byte(b.String()), 0644)
+}
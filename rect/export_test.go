@@ -0,0 +1,153 @@
+package rect
+
+import (
+	"image"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestMergeWallsCoalescesAdjacent verifies mergeWalls joins touching,
+// same-band horizontal rectangles into one long segment, and leaves
+// separated rectangles alone.
+func TestMergeWallsCoalescesAdjacent(t *testing.T) {
+	touching := []image.Rectangle{
+		image.Rect(0, 0, 5, 2),
+		image.Rect(5, 0, 10, 2),
+	}
+	got := mergeWalls(touching)
+	if len(got) != 1 {
+		t.Fatalf("got %d merged rects, want 1: touching rects in the same band should coalesce", len(got))
+	}
+	if want := image.Rect(0, 0, 10, 2); got[0] != want {
+		t.Fatalf("merged rect = %v, want %v", got[0], want)
+	}
+
+	separate := []image.Rectangle{
+		image.Rect(0, 0, 5, 2),
+		image.Rect(7, 0, 10, 2),
+	}
+	if got := mergeWalls(separate); len(got) != 2 {
+		t.Fatalf("got %d merged rects, want 2: non-touching rects must not coalesce", len(got))
+	}
+}
+
+// TestMergeWallsCoalescesOverlapping verifies mergeWalls also merges
+// vertical rectangles that overlap rather than merely touch.
+func TestMergeWallsCoalescesOverlapping(t *testing.T) {
+	overlapping := []image.Rectangle{
+		image.Rect(0, 0, 2, 6),
+		image.Rect(0, 4, 2, 10),
+	}
+	got := mergeWalls(overlapping)
+	if len(got) != 1 {
+		t.Fatalf("got %d merged rects, want 1: overlapping rects in the same band should coalesce", len(got))
+	}
+	if want := image.Rect(0, 0, 2, 10); got[0] != want {
+		t.Fatalf("merged rect = %v, want %v", got[0], want)
+	}
+}
+
+// TestMergeWallsKeepsHorizontalAndVerticalSeparate verifies mergeWalls never
+// merges a horizontal rectangle with a vertical one, even if they touch.
+func TestMergeWallsKeepsHorizontalAndVerticalSeparate(t *testing.T) {
+	mixed := []image.Rectangle{
+		image.Rect(0, 0, 5, 2),
+		image.Rect(0, 0, 2, 5),
+	}
+	if got := mergeWalls(mixed); len(got) != 2 {
+		t.Fatalf("got %d merged rects, want 2: a horizontal and a vertical rect must not merge", len(got))
+	}
+}
+
+// TestWriteWallPolylines checks the polyline exporter centers a single
+// merged wall segment and scales/offsets it by cellSize and origin.
+func TestWriteWallPolylines(t *testing.T) {
+	walls := []image.Rectangle{image.Rect(0, 0, 10, 2)}
+	got := WriteWallPolylines(walls, image.Point{X: 100, Y: 200}, 2)
+
+	if len(got) != 1 || len(got[0]) != 2 {
+		t.Fatalf("got %v, want a single two-point polyline", got)
+	}
+	want := []image.Point{{X: 100, Y: 202}, {X: 120, Y: 202}}
+	if got[0][0] != want[0] || got[0][1] != want[1] {
+		t.Fatalf("polyline = %v, want %v", got[0], want)
+	}
+}
+
+// TestWriteSVG checks WriteSVG writes a well-formed SVG document sized to
+// the merged walls' bounds.
+func TestWriteSVG(t *testing.T) {
+	walls := []image.Rectangle{image.Rect(0, 0, 10, 10)}
+	path := filepath.Join(t.TempDir(), "maze.svg")
+
+	if err := WriteSVG(walls, path); err != nil {
+		t.Fatalf("WriteSVG failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading written SVG: %v", err)
+	}
+	svg := string(data)
+	if !strings.Contains(svg, `width="10" height="10"`) {
+		t.Fatalf("SVG does not report the expected bounds:\n%s", svg)
+	}
+	if !strings.Contains(svg, `<rect x="0" y="0" width="10" height="10" fill="white"/>`) {
+		t.Fatalf("SVG does not contain the expected wall rect:\n%s", svg)
+	}
+}
+
+// TestWriteTMXSamplesTileCenter is a regression test for WriteTMX flagging a
+// tile as wall on any overlap with a wall rectangle: a north-wall rect spans
+// a tile's full width but only WallThickness of its height, so it must not
+// mark the tile solid unless it actually reaches the tile's center.
+func TestWriteTMXSamplesTileCenter(t *testing.T) {
+	const tileSize = 10
+	walls := []image.Rectangle{
+		image.Rect(0, 0, tileSize, 2), // a thin north-wall strip along tile (0,0)'s top edge
+	}
+	path := filepath.Join(t.TempDir(), "maze.tmx")
+
+	if err := WriteTMX(walls, image.Point{X: tileSize, Y: tileSize}, path, TMXOptions{TileSize: tileSize}); err != nil {
+		t.Fatalf("WriteTMX failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading written TMX: %v", err)
+	}
+	if !strings.Contains(string(data), "<data encoding=\"csv\">\n0\n") {
+		t.Fatalf("tile (0,0) should be open (its center is not covered by the thin wall strip):\n%s", data)
+	}
+}
+
+// TestWriteTMXRendersMazeWithBothTileKinds is a regression test for WriteTMX
+// producing an all-wall CSV layer at the TileSize callers actually reach
+// for (the generator's own CellSize): a real maze, rendered at that scale,
+// must contain both wall and open tiles.
+func TestWriteTMXRendersMazeWithBothTileKinds(t *testing.T) {
+	mg := NewMazeGenerator(4, 3, 10, 2)
+	walls := mg.GenerateMaze()
+	path := filepath.Join(t.TempDir(), "maze.tmx")
+
+	if err := WriteTMX(walls, mg.Size(), path, TMXOptions{TileSize: mg.CellSize}); err != nil {
+		t.Fatalf("WriteTMX failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading written TMX: %v", err)
+	}
+	start := strings.Index(string(data), "<data encoding=\"csv\">\n") + len("<data encoding=\"csv\">\n")
+	end := strings.Index(string(data), "    </data>")
+	csv := string(data)[start:end]
+
+	if !strings.Contains(csv, "0") {
+		t.Fatalf("CSV layer has no open tiles, want at least one:\n%s", csv)
+	}
+	if !strings.Contains(csv, "1") {
+		t.Fatalf("CSV layer has no wall tiles, want at least one:\n%s", csv)
+	}
+}
@@ -61,40 +61,34 @@ func NewMazeGenerator(width, height, cellSize, wallThickness int) *MazeGenerator
 	return mg
 }
 
-// GenerateMaze creates a maze using depth-first search algorithm
+// GenerateMaze creates a maze using RecursiveBacktracker, the algorithm
+// GenerateMaze has always used. Use GenerateMazeWith to pick a different
+// Algorithm.
 func (mg *MazeGenerator) GenerateMaze() []image.Rectangle {
-	// Start from top-left corner
-	stack := []*Cell{&mg.Grid[0][0]}
-	mg.Grid[0][0].Visited = true
-
-	for len(stack) > 0 {
-		current := stack[len(stack)-1]
-
-		// Get unvisited neighbors
-		neighbors := mg.getUnvisitedNeighbors(current)
-
-		if len(neighbors) > 0 {
-			// Choose random neighbor
-			next := neighbors[mg.rng.Intn(len(neighbors))]
-
-			// Remove wall between current and next
-			mg.removeWall(current, next)
-
-			// Mark next as visited and add to stack
-			next.Visited = true
-			stack = append(stack, next)
-		} else {
-			// Backtrack - pop from stack
-			stack = stack[:len(stack)-1]
-		}
-	}
+	return mg.GenerateMazeWith(RecursiveBacktracker{})
+}
 
-	// Convert remaining walls to rectangles
+// GenerateMazeWith creates a maze using the given Algorithm and returns the
+// remaining walls as rectangles.
+func (mg *MazeGenerator) GenerateMazeWith(algo Algorithm) []image.Rectangle {
+	algo.Carve(mg)
 	return mg.getWallRectangles()
 }
 
 // getUnvisitedNeighbors returns all unvisited neighboring cells
 func (mg *MazeGenerator) getUnvisitedNeighbors(cell *Cell) []*Cell {
+	var neighbors []*Cell
+	for _, n := range mg.getNeighbors(cell) {
+		if !n.Visited {
+			neighbors = append(neighbors, n)
+		}
+	}
+	return neighbors
+}
+
+// getNeighbors returns all in-bounds neighboring cells, regardless of
+// visited state.
+func (mg *MazeGenerator) getNeighbors(cell *Cell) []*Cell {
 	var neighbors []*Cell
 	x, y := cell.X, cell.Y
 
@@ -112,9 +106,7 @@ func (mg *MazeGenerator) getUnvisitedNeighbors(cell *Cell) []*Cell {
 	for _, d := range directions {
 		nx, ny := x+d.dx, y+d.dy
 		if nx >= 0 && nx < mg.Width && ny >= 0 && ny < mg.Height {
-			if !mg.Grid[ny][nx].Visited {
-				neighbors = append(neighbors, &mg.Grid[ny][nx])
-			}
+			neighbors = append(neighbors, &mg.Grid[ny][nx])
 		}
 	}
 
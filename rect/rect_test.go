@@ -0,0 +1,83 @@
+package rect
+
+import "testing"
+
+// rectAlgorithmsUnderTest lists every generation Algorithm this package
+// ships, so the connectivity check below runs against all of them.
+var rectAlgorithmsUnderTest = []struct {
+	name string
+	algo Algorithm
+}{
+	{"RecursiveBacktracker", RecursiveBacktracker{}},
+	{"BinaryTree", BinaryTree{}},
+	{"Sidewinder", Sidewinder{}},
+	{"Wilson", Wilson{}},
+	{"Prim", Prim{}},
+	{"Kruskal", Kruskal{}},
+}
+
+// TestAlgorithmsProduceSpanningTree verifies every Algorithm carves a
+// perfect maze: every cell reachable from (0, 0), with exactly
+// width*height-1 carved edges (no loops, no disconnected cells). This is
+// the invariant a buggy union-find (Kruskal) or a buggy loop-erased walk
+// (Wilson) would violate.
+func TestAlgorithmsProduceSpanningTree(t *testing.T) {
+	const width, height = 8, 8
+	for _, tc := range rectAlgorithmsUnderTest {
+		t.Run(tc.name, func(t *testing.T) {
+			mg := NewMazeGenerator(width, height, 4, 1)
+			mg.GenerateMazeWith(tc.algo)
+
+			visited := make(map[[2]int]bool)
+			queue := [][2]int{{0, 0}}
+			visited[[2]int{0, 0}] = true
+			edges := 0
+			for len(queue) > 0 {
+				cur := queue[0]
+				queue = queue[1:]
+				cell := &mg.Grid[cur[1]][cur[0]]
+				for _, n := range mg.getNeighbors(cell) {
+					if wallBetween(cell, n) {
+						continue
+					}
+					key := [2]int{n.X, n.Y}
+					if cur[0] == n.X && cur[1] < n.Y || cur[1] == n.Y && cur[0] < n.X {
+						edges++
+					}
+					if !visited[key] {
+						visited[key] = true
+						queue = append(queue, key)
+					}
+				}
+			}
+
+			for y := 0; y < height; y++ {
+				for x := 0; x < width; x++ {
+					if !visited[[2]int{x, y}] {
+						t.Fatalf("cell (%d,%d) unreachable from (0,0)", x, y)
+					}
+				}
+			}
+			if want := width*height - 1; edges != want {
+				t.Fatalf("got %d carved edges, want %d (spanning tree has a loop or is disconnected)", edges, want)
+			}
+		})
+	}
+}
+
+// wallBetween reports whether a wall still stands between two orthogonally
+// adjacent cells.
+func wallBetween(a, b *Cell) bool {
+	switch dx, dy := b.X-a.X, b.Y-a.Y; {
+	case dx == 1 && dy == 0:
+		return a.Walls[East]
+	case dx == -1 && dy == 0:
+		return a.Walls[West]
+	case dx == 0 && dy == 1:
+		return a.Walls[South]
+	case dx == 0 && dy == -1:
+		return a.Walls[North]
+	default:
+		return true
+	}
+}
@@ -14,9 +14,12 @@ func WritePNG[T constraints.Integer](grid [][]T, filename string) error {
 	for y, row := range grid {
 		for x, cell := range row {
 			var col color.Color
-			if cell == 1 {
+			switch cell {
+			case 1:
 				col = color.RGBA{0, 0, 255, 255}
-			} else {
+			case 2: // Weave bridge-shadow cells
+				col = color.RGBA{90, 90, 90, 255}
+			default:
 				col = color.Gray{30}
 			}
 			img.Set(x, y, col)
@@ -29,3 +32,125 @@ func WritePNG[T constraints.Integer](grid [][]T, filename string) error {
 	defer outFile.Close()
 	return png.Encode(outFile, img)
 }
+
+// WritePNGWithOverlay renders m like WritePNG, but additionally shades each
+// path cell by its normalized distance from d's start cell (a blue-to-white
+// heatmap ramp) and draws path, if non-nil, in a solid solution color. m
+// must have been generated first.
+func (m *Maze[T]) WritePNGWithOverlay(d *Distances, path []Coord, filename string) error {
+	onPath := make(map[Coord]bool, len(path))
+	for _, c := range path {
+		onPath[c] = true
+	}
+
+	maxDist := 0
+	for _, row := range d.dist {
+		for _, dist := range row {
+			if dist > maxDist {
+				maxDist = dist
+			}
+		}
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, len(m.Grid[0]), len(m.Grid)))
+	for y, row := range m.Grid {
+		for x, cell := range row {
+			if cell == 1 {
+				img.Set(x, y, color.RGBA{0, 0, 255, 255})
+				continue
+			}
+
+			r, c := m.CellAt(x, y)
+			if onPath[Coord{r, c}] {
+				img.Set(x, y, color.RGBA{255, 215, 0, 255})
+				continue
+			}
+
+			t := 0.0
+			if dist, ok := d.At(r, c); ok && maxDist > 0 {
+				t = float64(dist) / float64(maxDist)
+			}
+			img.Set(x, y, heatColor(t))
+		}
+	}
+
+	outFile, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer outFile.Close()
+	return png.Encode(outFile, img)
+}
+
+// portalPalette colors distinguish one portal pair from another; portal ids
+// beyond len(portalPalette) wrap around and reuse earlier colors.
+var portalPalette = []color.Color{
+	color.RGBA{255, 0, 255, 255},
+	color.RGBA{0, 255, 255, 255},
+	color.RGBA{255, 165, 0, 255},
+	color.RGBA{0, 255, 0, 255},
+}
+
+// WritePNGWithPortals renders m like WritePNG, but additionally paints each
+// portal's two endpoint cells in a shared, portal-specific color so linked
+// cells are visually identifiable.
+func (m *Maze[T]) WritePNGWithPortals(filename string) error {
+	img := image.NewRGBA(image.Rect(0, 0, len(m.Grid[0]), len(m.Grid)))
+	for y, row := range m.Grid {
+		for x, cell := range row {
+			if cell == 1 {
+				img.Set(x, y, color.RGBA{0, 0, 255, 255})
+				continue
+			}
+
+			if m.PortalID != nil {
+				r, c := m.CellAt(x, y)
+				if id := m.PortalID[r][c]; id != 0 {
+					img.Set(x, y, portalPalette[(id-1)%len(portalPalette)])
+					continue
+				}
+			}
+			img.Set(x, y, color.Gray{30})
+		}
+	}
+
+	outFile, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer outFile.Close()
+	return png.Encode(outFile, img)
+}
+
+// CellAt maps a pixel coordinate to the cell that contains it, clamping to
+// the grid bounds. Wall-opening pixels between two cells resolve to the cell
+// before them (the one to the north or west).
+func (m *Maze[T]) CellAt(x, y int) (r, c int) {
+	r = (y - m.WallThickness) / (m.CellSize + m.WallThickness)
+	c = (x - m.WallThickness) / (m.CellSize + m.WallThickness)
+	switch {
+	case r < 0:
+		r = 0
+	case r >= m.Rows:
+		r = m.Rows - 1
+	}
+	switch {
+	case c < 0:
+		c = 0
+	case c >= m.Cols:
+		c = m.Cols - 1
+	}
+	return r, c
+}
+
+// heatColor maps t (normalized distance in [0, 1]) to a blue-to-white ramp.
+func heatColor(t float64) color.Color {
+	switch {
+	case t < 0:
+		t = 0
+	case t > 1:
+		t = 1
+	}
+	v := uint8(40 + t*(255-40))
+	return color.RGBA{v, v, 255, 255}
+}